@@ -0,0 +1,17 @@
+package set
+
+// Clone returns an independent deep copy of s. Mutating the clone does
+// not affect s, and vice versa.
+func (s *Set[T]) Clone() *Set[T] {
+	return &Set[T]{cmp: s.cmp, size: s.size, root: deepCloneNode(s.root, nil)}
+}
+
+func deepCloneNode[T any](n, parent *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	c := &node[T]{value: n.value, color: n.color, size: n.size, parent: parent}
+	c.left = deepCloneNode(n.left, c)
+	c.right = deepCloneNode(n.right, c)
+	return c
+}
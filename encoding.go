@@ -0,0 +1,166 @@
+package set
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// FromSortedSlice builds a Set from xs in O(n) by constructing a
+// perfectly balanced red-black tree directly, which is dramatically
+// faster than n individual Insert calls. xs must be strictly ascending
+// and contain no duplicates; FromSortedSlice does not verify this.
+func FromSortedSlice[T Ordered](xs []T) *Set[T] {
+	return buildSorted(xs, compareOrdered[T])
+}
+
+// buildSorted builds a Set from xs and cmp in O(len(xs)), the same way
+// FromSortedSlice does; it exists so callers that already have a sorted
+// slice and a comparator (such as the set-algebra operations in
+// setops.go) don't have to go through n individual Insert calls just
+// because their element type isn't constrained to Ordered.
+func buildSorted[T any](xs []T, cmp func(a, b T) int) *Set[T] {
+	out := &Set[T]{cmp: cmp}
+	n := len(xs)
+	if n == 0 {
+		return out
+	}
+	out.root = buildFromSorted(xs, 0, n-1, 0, computeRedLevel(n))
+	out.size = n
+	return out
+}
+
+// computeRedLevel returns the 0-indexed depth at which nodes built by
+// buildFromSorted must be colored red so that an otherwise-complete tree
+// with an incomplete bottom level still satisfies the red-black
+// invariants.
+func computeRedLevel(sz int) int {
+	level := 0
+	for m := sz - 1; m >= 0; m = m/2 - 1 {
+		level++
+	}
+	return level
+}
+
+// buildFromSorted recursively builds a balanced subtree over xs[lo:hi+1],
+// coloring nodes at depth redLevel red and all others black. redLevel is
+// the bottom level when that level is incomplete; for a perfect tree it
+// lies below the tree, so every node is black.
+func buildFromSorted[T any](xs []T, lo, hi, level, redLevel int) *node[T] {
+	if hi < lo {
+		return nil
+	}
+	mid := (lo + hi) / 2
+
+	var left *node[T]
+	if lo < mid {
+		left = buildFromSorted(xs, lo, mid-1, level+1, redLevel)
+	}
+	var right *node[T]
+	if mid < hi {
+		right = buildFromSorted(xs, mid+1, hi, level+1, redLevel)
+	}
+
+	c := black
+	if level == redLevel {
+		c = red
+	}
+	n := &node[T]{value: xs[mid], color: c}
+	if left != nil {
+		n.left = left
+		left.parent = n
+	}
+	if right != nil {
+		n.right = right
+		right.parent = n
+	}
+	n.size = 1 + subtreeSize(n.left) + subtreeSize(n.right)
+	return n
+}
+
+// MarshalJSON encodes the set as a JSON array of its elements in
+// ascending order.
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	xs := make([]T, 0, s.size)
+	for v := range s.All() {
+		xs = append(xs, v)
+	}
+	return json.Marshal(xs)
+}
+
+// UnmarshalJSON decodes a JSON array of elements, replacing the set's
+// contents. s must already have a comparator, i.e. be the result of New
+// or NewWith (or a prior decode); decoding into a zero-value Set returns
+// an error instead of panicking on the first Insert.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	if s.cmp == nil {
+		return fmt.Errorf("set: UnmarshalJSON: set has no comparator; decode into one made with New or NewWith")
+	}
+	var xs []T
+	if err := json.Unmarshal(data, &xs); err != nil {
+		return err
+	}
+	s.root = nil
+	s.size = 0
+	for _, v := range xs {
+		s.Insert(v)
+	}
+	return nil
+}
+
+// MarshalBinary encodes the set as a length-prefixed stream of its
+// elements in ascending order, gob-encoded.
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.BigEndian, uint64(s.size)); err != nil {
+		return nil, err
+	}
+	enc := gob.NewEncoder(&buf)
+	for v := range s.All() {
+		if err := enc.Encode(&v); err != nil {
+			return nil, fmt.Errorf("set: encode element: %w", err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary, replacing the
+// set's contents. s must already have a comparator, i.e. be the result of
+// New or NewWith (or a prior decode); decoding into a zero-value Set
+// returns an error instead of panicking on the first Insert.
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	if s.cmp == nil {
+		return fmt.Errorf("set: UnmarshalBinary: set has no comparator; decode into one made with New or NewWith")
+	}
+	buf := bytes.NewReader(data)
+	var n uint64
+	if err := binary.Read(buf, binary.BigEndian, &n); err != nil {
+		return err
+	}
+	dec := gob.NewDecoder(buf)
+	s.root = nil
+	s.size = 0
+	for i := uint64(0); i < n; i++ {
+		var v T
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("set: decode element: %w", err)
+		}
+		s.Insert(v)
+	}
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder in terms of MarshalBinary.
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder in terms of UnmarshalBinary. gob
+// materializes the destination *Set[T] itself when decoding into an
+// interface or a nil pointer, which produces a zero-value set with no
+// comparator; decode into a *Set[T] obtained from New or NewWith instead.
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
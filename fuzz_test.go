@@ -0,0 +1,70 @@
+//go:build debug
+
+package set
+
+import (
+	"encoding/binary"
+	"sort"
+	"testing"
+)
+
+// FuzzSet drives a Set[uint32] with a random sequence of Insert/Remove
+// operations decoded from data, mirrors them against a plain Go map, and
+// checks that the tree's invariants and contents stay in sync with the
+// map throughout.
+func FuzzSet(f *testing.F) {
+	f.Add([]byte{0, 0, 0, 0, 1, 1, 0, 0, 0, 1})
+	// Insert 10, 5, 15, 20 then remove 5, which deletes a black leaf and
+	// must rebalance rather than silently skip deleteFixup.
+	f.Add([]byte{
+		0, 0, 0, 0, 10,
+		0, 0, 0, 0, 5,
+		0, 0, 0, 0, 15,
+		0, 0, 0, 0, 20,
+		1, 0, 0, 0, 5,
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s := New[uint32]()
+		ref := make(map[uint32]struct{})
+
+		for len(data) >= 5 {
+			op := data[0]
+			v := binary.BigEndian.Uint32(data[1:5])
+			data = data[5:]
+
+			if op&1 == 0 {
+				s.Insert(v)
+				ref[v] = struct{}{}
+			} else {
+				s.Remove(v)
+				delete(ref, v)
+			}
+
+			if err := s.Validate(); err != nil {
+				t.Fatalf("invariant violated: %v", err)
+			}
+			if s.Len() != len(ref) {
+				t.Fatalf("Len() = %d, want %d", s.Len(), len(ref))
+			}
+		}
+
+		want := make([]uint32, 0, len(ref))
+		for v := range ref {
+			want = append(want, v)
+		}
+		sort.Slice(want, func(i, j int) bool { return want[i] < want[j] })
+
+		got := make([]uint32, 0, len(ref))
+		for v := range s.All() {
+			got = append(got, v)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("All() yielded %d elements, want %d", len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Fatalf("All()[%d] = %d, want %d", i, got[i], want[i])
+			}
+		}
+	})
+}
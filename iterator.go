@@ -0,0 +1,130 @@
+package set
+
+// Iterator is a stateful, bidirectional cursor over a Set. Unlike the
+// iter.Seq returned by All, it can be paused, resumed, and moved
+// backward. The zero value is not usable; obtain one via Set.Iterator,
+// Set.SeekCeiling, or Set.SeekFloor.
+type Iterator[T any] struct {
+	s       *Set[T]
+	node    *node[T]
+	started bool
+}
+
+// Iterator returns a cursor positioned before the first element. Call
+// Next to advance to it.
+func (s *Set[T]) Iterator() *Iterator[T] {
+	return &Iterator[T]{s: s}
+}
+
+// SeekCeiling returns a cursor positioned at the smallest element greater
+// than or equal to x. If no such element exists, the cursor is exhausted:
+// both Next and Prev report false.
+func (s *Set[T]) SeekCeiling(x T) *Iterator[T] {
+	return &Iterator[T]{s: s, node: s.ceilingNode(x), started: true}
+}
+
+// SeekFloor returns a cursor positioned at the largest element less than
+// or equal to x. If no such element exists, the cursor is exhausted: both
+// Next and Prev report false.
+func (s *Set[T]) SeekFloor(x T) *Iterator[T] {
+	return &Iterator[T]{s: s, node: s.floorNode(x), started: true}
+}
+
+func (s *Set[T]) firstNode() *node[T] {
+	if s.root == nil {
+		return nil
+	}
+	return s.minNode(s.root)
+}
+
+func (s *Set[T]) lastNode() *node[T] {
+	if s.root == nil {
+		return nil
+	}
+	return s.maxNode(s.root)
+}
+
+// successor returns n's in-order successor using parent pointers.
+func successor[T any](n *node[T]) *node[T] {
+	if n.right != nil {
+		n = n.right
+		for n.left != nil {
+			n = n.left
+		}
+		return n
+	}
+	p := n.parent
+	for p != nil && n == p.right {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// predecessor returns n's in-order predecessor using parent pointers.
+func predecessor[T any](n *node[T]) *node[T] {
+	if n.left != nil {
+		n = n.left
+		for n.right != nil {
+			n = n.right
+		}
+		return n
+	}
+	p := n.parent
+	for p != nil && n == p.left {
+		n = p
+		p = p.parent
+	}
+	return p
+}
+
+// Next advances the cursor to the next element in ascending order and
+// reports whether there was one.
+func (it *Iterator[T]) Next() bool {
+	if !it.started {
+		it.started = true
+		it.node = it.s.firstNode()
+		return it.node != nil
+	}
+	if it.node == nil {
+		return false
+	}
+	it.node = successor(it.node)
+	return it.node != nil
+}
+
+// Prev moves the cursor to the previous element in ascending order and
+// reports whether there was one.
+func (it *Iterator[T]) Prev() bool {
+	if !it.started {
+		it.started = true
+		it.node = it.s.lastNode()
+		return it.node != nil
+	}
+	if it.node == nil {
+		return false
+	}
+	it.node = predecessor(it.node)
+	return it.node != nil
+}
+
+// Value returns the element at the cursor's current position, or the
+// zero value if Next/Prev has not yet reported an element.
+func (it *Iterator[T]) Value() T {
+	if it.node == nil {
+		var zero T
+		return zero
+	}
+	return it.node.value
+}
+
+// Remove deletes the element at the cursor's current position from the
+// underlying set and repositions the cursor to its successor.
+func (it *Iterator[T]) Remove() {
+	if it.node == nil {
+		return
+	}
+	succ := successor(it.node)
+	it.s.deleteNode(it.node)
+	it.node = succ
+}
@@ -0,0 +1,166 @@
+package set
+
+import (
+	"iter"
+	"math"
+)
+
+// Ceiling returns the smallest element greater than or equal to x.
+func (s *Set[T]) Ceiling(x T) (T, bool) {
+	n := s.ceilingNode(x)
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Floor returns the largest element less than or equal to x.
+func (s *Set[T]) Floor(x T) (T, bool) {
+	n := s.floorNode(x)
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Higher returns the smallest element strictly greater than x.
+func (s *Set[T]) Higher(x T) (T, bool) {
+	n := s.higherNode(x)
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Lower returns the largest element strictly less than x.
+func (s *Set[T]) Lower(x T) (T, bool) {
+	n := s.lowerNode(x)
+	if n == nil {
+		var zero T
+		return zero, false
+	}
+	return n.value, true
+}
+
+func (s *Set[T]) ceilingNode(x T) *node[T] {
+	n := s.root
+	var candidate *node[T]
+	for n != nil {
+		switch c := s.cmp(x, n.value); {
+		case c == 0:
+			return n
+		case c < 0:
+			candidate = n
+			n = n.left
+		default:
+			n = n.right
+		}
+	}
+	return candidate
+}
+
+func (s *Set[T]) floorNode(x T) *node[T] {
+	n := s.root
+	var candidate *node[T]
+	for n != nil {
+		switch c := s.cmp(x, n.value); {
+		case c == 0:
+			return n
+		case c > 0:
+			candidate = n
+			n = n.right
+		default:
+			n = n.left
+		}
+	}
+	return candidate
+}
+
+func (s *Set[T]) higherNode(x T) *node[T] {
+	n := s.root
+	var candidate *node[T]
+	for n != nil {
+		if s.cmp(x, n.value) < 0 {
+			candidate = n
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+	return candidate
+}
+
+func (s *Set[T]) lowerNode(x T) *node[T] {
+	n := s.root
+	var candidate *node[T]
+	for n != nil {
+		if s.cmp(x, n.value) > 0 {
+			candidate = n
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return candidate
+}
+
+// Between yields the elements in the closed or open range [lo, hi] in
+// ascending order, descending only into the subtrees that can contain such
+// elements rather than visiting the whole tree. When inclusive is false,
+// lo and hi themselves are excluded.
+func (s *Set[T]) Between(lo, hi T, inclusive bool) iter.Seq[T] {
+	return func(yield func(v T) bool) {
+		var walk func(n *node[T]) bool
+		walk = func(n *node[T]) bool {
+			if n == nil {
+				return true
+			}
+			cLo := s.cmp(n.value, lo)
+			cHi := s.cmp(n.value, hi)
+			if cLo > 0 {
+				if !walk(n.left) {
+					return false
+				}
+			}
+			if cLo > 0 || (inclusive && cLo == 0) {
+				if cHi < 0 || (inclusive && cHi == 0) {
+					if !yield(n.value) {
+						return false
+					}
+				}
+			}
+			if cHi < 0 {
+				if !walk(n.right) {
+					return false
+				}
+			}
+			return true
+		}
+		walk(s.root)
+	}
+}
+
+// Reverse returns an iterator over the set in descending order.
+func (s *Set[T]) Reverse() iter.Seq[T] {
+	return func(yield func(v T) bool) {
+		maxSize := int(math.Floor(math.Log2(float64(s.size+2)/float64(5))) + 2)
+		stack := make([]*node[T], 0, maxSize)
+		n := s.root
+		for len(stack) > 0 || n != nil {
+			if n != nil {
+				stack = append(stack, n)
+				n = n.right
+			} else {
+				n = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				if !yield(n.value) {
+					return
+				}
+				n = n.left
+			}
+		}
+	}
+}
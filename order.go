@@ -0,0 +1,41 @@
+package set
+
+// Select returns the k-th smallest element in the set (0-indexed), i.e.
+// the element that would be at index k if the set were iterated in
+// ascending order. It runs in O(log n) using the per-node subtree sizes.
+func (s *Set[T]) Select(k int) (T, bool) {
+	if k < 0 || k >= s.size {
+		var zero T
+		return zero, false
+	}
+	n := s.root
+	for n != nil {
+		leftSize := subtreeSize(n.left)
+		switch {
+		case k < leftSize:
+			n = n.left
+		case k == leftSize:
+			return n.value, true
+		default:
+			k -= leftSize + 1
+			n = n.right
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// Rank returns the number of elements in the set strictly less than x.
+func (s *Set[T]) Rank(x T) int {
+	n := s.root
+	rank := 0
+	for n != nil {
+		if s.cmp(x, n.value) > 0 {
+			rank += subtreeSize(n.left) + 1
+			n = n.right
+		} else {
+			n = n.left
+		}
+	}
+	return rank
+}
@@ -0,0 +1,348 @@
+package set
+
+// clonePersistNode returns a shallow copy of n, or nil if n is nil. The
+// copy's parent/left/right pointers start out identical to n's; callers
+// are responsible for rewiring them.
+func clonePersistNode[T any](n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	c := *n
+	return &c
+}
+
+// own returns a fresh copy of n, relinked in place of n under n's
+// current parent (which must already be owned by out, or be nil). It
+// lets a persistent mutation touch a node without disturbing the
+// previous snapshot that may still reference it.
+func (out *Set[T]) own(n *node[T]) *node[T] {
+	fresh := clonePersistNode(n)
+	if fresh.parent == nil {
+		out.root = fresh
+	} else if fresh.parent.left == n {
+		fresh.parent.left = fresh
+	} else {
+		fresh.parent.right = fresh
+	}
+	if fresh.left != nil {
+		fresh.left.parent = fresh
+	}
+	if fresh.right != nil {
+		fresh.right.parent = fresh
+	}
+	return fresh
+}
+
+// ownChild is own, except it tolerates (and passes through) a nil n. Use
+// it for a node that may or may not be present, such as the child that
+// replaces a deleted node.
+func (out *Set[T]) ownChild(n *node[T]) *node[T] {
+	if n == nil {
+		return nil
+	}
+	return out.own(n)
+}
+
+// ownPath returns a fresh copy of n with every ancestor of n up to the
+// root also freshly copied and linked into out, sharing n's untouched
+// sibling subtrees with the previous snapshot.
+func (out *Set[T]) ownPath(n *node[T]) *node[T] {
+	if n.parent == nil {
+		return out.own(n)
+	}
+	freshParent := out.ownPath(n.parent)
+	fresh := clonePersistNode(n)
+	fresh.parent = freshParent
+	if n.parent.left == n {
+		freshParent.left = fresh
+	} else {
+		freshParent.right = fresh
+	}
+	if fresh.left != nil {
+		fresh.left.parent = fresh
+	}
+	if fresh.right != nil {
+		fresh.right.parent = fresh
+	}
+	return fresh
+}
+
+// ownMinPath clones n and every node on its left spine down to the
+// minimum, attaching the fresh copies under the already-owned parent.
+// It returns the fresh copy of the minimum node.
+func (out *Set[T]) ownMinPath(parent, n *node[T]) *node[T] {
+	fresh := clonePersistNode(n)
+	fresh.parent = parent
+	if parent.left == n {
+		parent.left = fresh
+	} else {
+		parent.right = fresh
+	}
+	if fresh.right != nil {
+		fresh.right.parent = fresh
+	}
+	if fresh.left == nil {
+		return fresh
+	}
+	return out.ownMinPath(fresh, fresh.left)
+}
+
+// Persistent is an opt-in, copy-on-write variant of Set built for
+// MVCC-style read-heavy workloads: Insert and Remove never mutate a
+// previous snapshot, and instead return a new, independent snapshot that
+// shares unchanged subtrees with it. Snapshots are O(1) to take and each
+// mutation costs O(log n) allocation. A snapshot supports every read-only
+// Set operation (Contains, All, Ceiling/Floor, Select/Rank,
+// Between/Reverse, MarshalJSON, ...); it must not be passed to Insert,
+// Remove, or Iterator, since those rely on parent pointers, and each
+// mutation repoints the parent pointers of the shared subtrees hanging off
+// its freshly copied nodes into the new snapshot.
+type Persistent[T Ordered] struct {
+	snapshot *Set[T]
+}
+
+// NewPersistent returns an empty persistent set.
+func NewPersistent[T Ordered]() *Persistent[T] {
+	return &Persistent[T]{snapshot: New[T]()}
+}
+
+// Snapshot returns the current snapshot.
+func (p *Persistent[T]) Snapshot() *Set[T] {
+	return p.snapshot
+}
+
+// Insert returns a new snapshot with x inserted and makes it current,
+// leaving every previously returned snapshot unchanged.
+func (p *Persistent[T]) Insert(x T) *Set[T] {
+	next := persistInsert(p.snapshot, x)
+	p.snapshot = next
+	return next
+}
+
+// Remove returns a new snapshot with x removed and makes it current,
+// leaving every previously returned snapshot unchanged.
+func (p *Persistent[T]) Remove(x T) *Set[T] {
+	next := persistRemove(p.snapshot, x)
+	p.snapshot = next
+	return next
+}
+
+func persistInsert[T any](s *Set[T], x T) *Set[T] {
+	if s.root == nil {
+		return &Set[T]{cmp: s.cmp, root: &node[T]{value: x, color: black, size: 1}, size: 1}
+	}
+
+	var parent *node[T]
+	n := s.root
+	var c int
+	for n != nil {
+		parent = n
+		c = s.cmp(x, n.value)
+		if c == 0 {
+			return s
+		} else if c < 0 {
+			n = n.left
+		} else {
+			n = n.right
+		}
+	}
+
+	out := &Set[T]{cmp: s.cmp, size: s.size}
+	freshParent := out.ownPath(parent)
+	newNode := &node[T]{value: x, color: red, size: 1, parent: freshParent}
+	if c < 0 {
+		freshParent.left = newNode
+	} else {
+		freshParent.right = newNode
+	}
+	out.adjustSizes(freshParent, 1)
+	persistInsertFixup(out, newNode)
+	out.size++
+	return out
+}
+
+// persistInsertFixup mirrors (*Set[T]).insertFixup, but owns the uncle
+// before recoloring it so that the previous snapshot's subtree is left
+// untouched. z and every ancestor up to the root are already fresh
+// copies owned by out (via ownPath), so only the uncle ever needs
+// owning; the rotations below touch only that already-owned path.
+func persistInsertFixup[T any](out *Set[T], z *node[T]) {
+	for z.parent != nil && z.parent.color == red {
+		grandparent := z.parent.parent
+		if z.parent == grandparent.left {
+			if grandparent.right != nil && grandparent.right.color == red {
+				y := out.own(grandparent.right)
+				z.parent.color = black
+				y.color = black
+				grandparent.color = red
+				z = grandparent
+			} else {
+				if z == z.parent.right {
+					z = z.parent
+					out.leftRotate(z)
+				}
+				z.parent.color = black
+				z.parent.parent.color = red
+				out.rightRotate(z.parent.parent)
+			}
+		} else {
+			if grandparent.left != nil && grandparent.left.color == red {
+				y := out.own(grandparent.left)
+				z.parent.color = black
+				y.color = black
+				grandparent.color = red
+				z = grandparent
+			} else {
+				if z == z.parent.left {
+					z = z.parent
+					out.rightRotate(z)
+				}
+				z.parent.color = black
+				z.parent.parent.color = red
+				out.leftRotate(z.parent.parent)
+			}
+		}
+	}
+	out.root.color = black
+}
+
+func persistRemove[T any](s *Set[T], x T) *Set[T] {
+	z := s.root
+	for z != nil {
+		c := s.cmp(x, z.value)
+		if c == 0 {
+			break
+		} else if c < 0 {
+			z = z.left
+		} else {
+			z = z.right
+		}
+	}
+	if z == nil {
+		return s
+	}
+
+	out := &Set[T]{cmp: s.cmp, size: s.size - 1}
+	freshZ := out.ownPath(z)
+
+	var xNode, y, xp *node[T]
+	y = freshZ
+	originalColor := y.color
+	if freshZ.left == nil {
+		// freshZ.right is about to become x in persistDeleteFixup, which
+		// may recolor it directly (e.g. the final x.color = black), so it
+		// must be owned rather than the previous snapshot's shared node.
+		xNode = out.ownChild(freshZ.right)
+		xp = freshZ.parent
+		out.adjustSizes(freshZ.parent, -1)
+		out.rbTransplant(freshZ, xNode)
+	} else if freshZ.right == nil {
+		xNode = out.ownChild(freshZ.left)
+		xp = freshZ.parent
+		out.adjustSizes(freshZ.parent, -1)
+		out.rbTransplant(freshZ, xNode)
+	} else {
+		freshSucc := out.ownMinPath(freshZ, freshZ.right)
+		y = freshSucc
+		originalColor = y.color
+		xNode = out.ownChild(y.right)
+		if y.parent == freshZ {
+			xp = y
+			out.adjustSizes(freshZ.parent, -1)
+		} else {
+			xp = y.parent
+			out.adjustSizes(y.parent, -1)
+			out.rbTransplant(y, xNode)
+			y.right = freshZ.right
+			y.right.parent = y
+		}
+		out.rbTransplant(freshZ, y)
+		y.left = freshZ.left
+		y.left.parent = y
+		y.color = freshZ.color
+		y.size = 1 + subtreeSize(y.left) + subtreeSize(y.right)
+	}
+
+	if originalColor == black {
+		persistDeleteFixup(out, xNode, xp)
+	}
+	return out
+}
+
+// persistDeleteFixup mirrors (*Set[T]).deleteFixup, but owns every
+// sibling before the rotations that mutate it so that the previous
+// snapshot's subtree is left untouched. x may be nil (an implicit black
+// NIL leaf), so its parent xp is passed in separately since a nil x has
+// no parent pointer of its own to recover it from.
+func persistDeleteFixup[T any](out *Set[T], x, xp *node[T]) {
+	for x != out.root && nodeColor(x) == black {
+		if x == xp.left {
+			w := out.own(xp.right)
+			if w.color == red {
+				w.color = black
+				xp.color = red
+				out.leftRotate(xp)
+				w = out.own(xp.right)
+			}
+			if nodeColor(w.left) == black && nodeColor(w.right) == black {
+				w.color = red
+				x = xp
+				xp = x.parent
+			} else {
+				if nodeColor(w.right) == black {
+					if w.left != nil {
+						w.left = out.own(w.left)
+						w.left.color = black
+					}
+					w.color = red
+					out.rightRotate(w)
+					w = out.own(xp.right)
+				}
+				w.color = xp.color
+				xp.color = black
+				if w.right != nil {
+					w.right = out.own(w.right)
+					w.right.color = black
+				}
+				out.leftRotate(xp)
+				x = out.root
+				xp = nil
+			}
+		} else {
+			w := out.own(xp.left)
+			if w.color == red {
+				w.color = black
+				xp.color = red
+				out.rightRotate(xp)
+				w = out.own(xp.left)
+			}
+			if nodeColor(w.right) == black && nodeColor(w.left) == black {
+				w.color = red
+				x = xp
+				xp = x.parent
+			} else {
+				if nodeColor(w.left) == black {
+					if w.right != nil {
+						w.right = out.own(w.right)
+						w.right.color = black
+					}
+					w.color = red
+					out.leftRotate(w)
+					w = out.own(xp.left)
+				}
+				w.color = xp.color
+				xp.color = black
+				if w.left != nil {
+					w.left = out.own(w.left)
+					w.left.color = black
+				}
+				out.rightRotate(xp)
+				x = out.root
+				xp = nil
+			}
+		}
+	}
+	if x != nil {
+		x.color = black
+	}
+}
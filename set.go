@@ -14,7 +14,7 @@ type signed interface {
 }
 
 type unsigned interface {
-	~uint | ~uint8 | ~int16 | ~int32 | ~int64 | ~uintptr
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
 }
 
 type Ordered interface {
@@ -28,22 +28,74 @@ const (
 	black color = false
 )
 
-type node[T Ordered] struct {
+type node[T any] struct {
 	value  T
 	color  color
+	size   int // size of the subtree rooted at this node, including itself
 	left   *node[T]
 	right  *node[T]
 	parent *node[T]
 }
 
-type Set[T Ordered] struct {
+// subtreeSize returns the size of the subtree rooted at n, treating a nil
+// node as an empty subtree.
+func subtreeSize[T any](n *node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// nodeColor returns the color of n, treating a nil node as black, as the
+// implicit NIL leaves of a red-black tree are by definition.
+func nodeColor[T any](n *node[T]) color {
+	if n == nil {
+		return black
+	}
+	return n.color
+}
+
+// adjustSizes adds delta to n's size and to every ancestor of n.
+func (s *Set[T]) adjustSizes(n *node[T], delta int) {
+	for p := n; p != nil; p = p.parent {
+		p.size += delta
+	}
+}
+
+// Set is a red-black tree backed ordered set. Elements are ordered by the
+// comparator the set was constructed with; see New and NewWith.
+type Set[T any] struct {
 	root *node[T]
 	size int
+	cmp  func(a, b T) int
 }
 
-// New returns an empty set.
+// compareOrdered is the default comparator for New, implemented directly
+// with the < and > operators. Like any other comparator, it is still
+// invoked through s.cmp, so it does not avoid the indirect call NewWith's
+// comparator pays; it just spares the Ordered common case from having to
+// supply one.
+func compareOrdered[T Ordered](a, b T) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// New returns an empty set ordered by the natural < and > operators of T.
 func New[T Ordered]() *Set[T] {
-	return &Set[T]{}
+	return &Set[T]{cmp: compareOrdered[T]}
+}
+
+// NewWith returns an empty set ordered by less, which must return a
+// negative number if a < b, zero if a == b, and a positive number if
+// a > b. Use this to store element types that don't satisfy Ordered, such
+// as structs, time.Time, or case-insensitive strings.
+func NewWith[T any](less func(a, b T) int) *Set[T] {
+	return &Set[T]{cmp: less}
 }
 
 // Len returns the number of elements in the set.
@@ -55,11 +107,12 @@ func (s *Set[T]) Len() int {
 func (s *Set[T]) Contains(x T) bool {
 	n := s.root
 	for n != nil {
-		if x < n.value {
+		switch c := s.cmp(x, n.value); {
+		case c < 0:
 			n = n.left
-		} else if x > n.value {
+		case c > 0:
 			n = n.right
-		} else {
+		default:
 			return true
 		}
 	}
@@ -71,11 +124,13 @@ func (s *Set[T]) Insert(x T) {
 	// Standard BST insert
 	var parent *node[T]
 	n := s.root
+	var c int
 	for n != nil {
 		parent = n
-		if x < n.value {
+		c = s.cmp(x, n.value)
+		if c < 0 {
 			n = n.left
-		} else if x > n.value {
+		} else if c > 0 {
 			n = n.right
 		} else {
 			// Element already in the set
@@ -83,15 +138,16 @@ func (s *Set[T]) Insert(x T) {
 		}
 	}
 
-	newNode := &node[T]{value: x, color: red, parent: parent}
+	newNode := &node[T]{value: x, color: red, size: 1, parent: parent}
 	if parent == nil {
 		// Tree was empty
 		s.root = newNode
-	} else if x < parent.value {
+	} else if c < 0 {
 		parent.left = newNode
 	} else {
 		parent.right = newNode
 	}
+	s.adjustSizes(parent, 1)
 
 	s.insertFixup(newNode)
 	s.size++
@@ -153,6 +209,8 @@ func (s *Set[T]) leftRotate(x *node[T]) {
 	}
 	y.left = x
 	x.parent = y
+	x.size = 1 + subtreeSize(x.left) + subtreeSize(x.right)
+	y.size = 1 + subtreeSize(y.left) + subtreeSize(y.right)
 }
 
 func (s *Set[T]) rightRotate(x *node[T]) {
@@ -171,17 +229,20 @@ func (s *Set[T]) rightRotate(x *node[T]) {
 	}
 	y.right = x
 	x.parent = y
+	x.size = 1 + subtreeSize(x.left) + subtreeSize(x.right)
+	y.size = 1 + subtreeSize(y.left) + subtreeSize(y.right)
 }
 
 // Remove deletes x from the set if it exists.
 func (s *Set[T]) Remove(x T) {
 	z := s.root
 	for z != nil {
-		if x < z.value {
+		switch c := s.cmp(x, z.value); {
+		case c < 0:
 			z = z.left
-		} else if x > z.value {
+		case c > 0:
 			z = z.right
-		} else {
+		default:
 			s.deleteNode(z)
 			return
 		}
@@ -191,13 +252,18 @@ func (s *Set[T]) Remove(x T) {
 // deleteNode removes a given node from the red-black tree.
 func (s *Set[T]) deleteNode(z *node[T]) {
 	var x, y *node[T]
+	var xp *node[T] // parent x will have once it's spliced in, even if x is nil
 	y = z
 	originalColor := y.color
 	if z.left == nil {
 		x = z.right
+		xp = z.parent
+		s.adjustSizes(z.parent, -1)
 		s.rbTransplant(z, z.right)
 	} else if z.right == nil {
 		x = z.left
+		xp = z.parent
+		s.adjustSizes(z.parent, -1)
 		s.rbTransplant(z, z.left)
 	} else {
 		// Find successor
@@ -205,10 +271,14 @@ func (s *Set[T]) deleteNode(z *node[T]) {
 		originalColor = y.color
 		x = y.right
 		if y.parent == z {
+			xp = y
 			if x != nil {
 				x.parent = y
 			}
+			s.adjustSizes(z.parent, -1)
 		} else {
+			xp = y.parent
+			s.adjustSizes(y.parent, -1)
 			s.rbTransplant(y, y.right)
 			y.right = z.right
 			y.right.parent = y
@@ -217,10 +287,11 @@ func (s *Set[T]) deleteNode(z *node[T]) {
 		y.left = z.left
 		y.left.parent = y
 		y.color = z.color
+		y.size = 1 + subtreeSize(y.left) + subtreeSize(y.right)
 	}
 	s.size--
-	if originalColor == black && x != nil {
-		s.deleteFixup(x)
+	if originalColor == black {
+		s.deleteFixup(x, xp)
 	}
 }
 
@@ -238,68 +309,77 @@ func (s *Set[T]) rbTransplant(u, v *node[T]) {
 	}
 }
 
-// deleteFixup restores Red-Black properties after deletion.
-func (s *Set[T]) deleteFixup(x *node[T]) {
-	for x != s.root && x.color == black {
-		if x == x.parent.left {
-			w := x.parent.right
+// deleteFixup restores Red-Black properties after deletion. x is the node
+// that replaced the deleted black node and may be nil (an implicit black
+// NIL leaf), so its parent xp is passed in separately since a nil x has no
+// parent pointer of its own to recover it from.
+func (s *Set[T]) deleteFixup(x, xp *node[T]) {
+	for x != s.root && nodeColor(x) == black {
+		if x == xp.left {
+			w := xp.right
 			if w.color == red {
 				w.color = black
-				x.parent.color = red
-				s.leftRotate(x.parent)
-				w = x.parent.right
+				xp.color = red
+				s.leftRotate(xp)
+				w = xp.right
 			}
-			if (w.left == nil || w.left.color == black) && (w.right == nil || w.right.color == black) {
+			if nodeColor(w.left) == black && nodeColor(w.right) == black {
 				w.color = red
-				x = x.parent
+				x = xp
+				xp = x.parent
 			} else {
-				if w.right == nil || w.right.color == black {
+				if nodeColor(w.right) == black {
 					if w.left != nil {
 						w.left.color = black
 					}
 					w.color = red
 					s.rightRotate(w)
-					w = x.parent.right
+					w = xp.right
 				}
-				w.color = x.parent.color
-				x.parent.color = black
+				w.color = xp.color
+				xp.color = black
 				if w.right != nil {
 					w.right.color = black
 				}
-				s.leftRotate(x.parent)
+				s.leftRotate(xp)
 				x = s.root
+				xp = nil
 			}
 		} else {
-			w := x.parent.left
+			w := xp.left
 			if w.color == red {
 				w.color = black
-				x.parent.color = red
-				s.rightRotate(x.parent)
-				w = x.parent.left
+				xp.color = red
+				s.rightRotate(xp)
+				w = xp.left
 			}
-			if (w.right == nil || w.right.color == black) && (w.left == nil || w.left.color == black) {
+			if nodeColor(w.right) == black && nodeColor(w.left) == black {
 				w.color = red
-				x = x.parent
+				x = xp
+				xp = x.parent
 			} else {
-				if w.left == nil || w.left.color == black {
+				if nodeColor(w.left) == black {
 					if w.right != nil {
 						w.right.color = black
 					}
 					w.color = red
 					s.leftRotate(w)
-					w = x.parent.left
+					w = xp.left
 				}
-				w.color = x.parent.color
-				x.parent.color = black
+				w.color = xp.color
+				xp.color = black
 				if w.left != nil {
 					w.left.color = black
 				}
-				s.rightRotate(x.parent)
+				s.rightRotate(xp)
 				x = s.root
+				xp = nil
 			}
 		}
 	}
-	x.color = black
+	if x != nil {
+		x.color = black
+	}
 }
 
 // Min returns the smallest element in the set.
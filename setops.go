@@ -0,0 +1,193 @@
+package set
+
+import "iter"
+
+// Union returns a new set containing every element that appears in a or b.
+// It merges the two sets' sorted iteration order in a single O(n+m) pass
+// rather than probing one set with the other's Contains, and builds the
+// result directly from that sorted run rather than through repeated
+// Insert calls.
+func Union[T any](a, b *Set[T]) *Set[T] {
+	xs := make([]T, 0, a.size+b.size)
+	next1, stop1 := iter.Pull(a.All())
+	defer stop1()
+	next2, stop2 := iter.Pull(b.All())
+	defer stop2()
+	v1, ok1 := next1()
+	v2, ok2 := next2()
+	for ok1 && ok2 {
+		switch c := a.cmp(v1, v2); {
+		case c < 0:
+			xs = append(xs, v1)
+			v1, ok1 = next1()
+		case c > 0:
+			xs = append(xs, v2)
+			v2, ok2 = next2()
+		default:
+			xs = append(xs, v1)
+			v1, ok1 = next1()
+			v2, ok2 = next2()
+		}
+	}
+	for ok1 {
+		xs = append(xs, v1)
+		v1, ok1 = next1()
+	}
+	for ok2 {
+		xs = append(xs, v2)
+		v2, ok2 = next2()
+	}
+	return buildSorted(xs, a.cmp)
+}
+
+// Intersection returns a new set containing only the elements that appear
+// in both a and b.
+func Intersection[T any](a, b *Set[T]) *Set[T] {
+	var xs []T
+	next1, stop1 := iter.Pull(a.All())
+	defer stop1()
+	next2, stop2 := iter.Pull(b.All())
+	defer stop2()
+	v1, ok1 := next1()
+	v2, ok2 := next2()
+	for ok1 && ok2 {
+		switch c := a.cmp(v1, v2); {
+		case c < 0:
+			v1, ok1 = next1()
+		case c > 0:
+			v2, ok2 = next2()
+		default:
+			xs = append(xs, v1)
+			v1, ok1 = next1()
+			v2, ok2 = next2()
+		}
+	}
+	return buildSorted(xs, a.cmp)
+}
+
+// Difference returns a new set containing the elements of a that are not
+// in b.
+func Difference[T any](a, b *Set[T]) *Set[T] {
+	xs := make([]T, 0, a.size)
+	next1, stop1 := iter.Pull(a.All())
+	defer stop1()
+	next2, stop2 := iter.Pull(b.All())
+	defer stop2()
+	v1, ok1 := next1()
+	v2, ok2 := next2()
+	for ok1 && ok2 {
+		switch c := a.cmp(v1, v2); {
+		case c < 0:
+			xs = append(xs, v1)
+			v1, ok1 = next1()
+		case c > 0:
+			v2, ok2 = next2()
+		default:
+			v1, ok1 = next1()
+			v2, ok2 = next2()
+		}
+	}
+	for ok1 {
+		xs = append(xs, v1)
+		v1, ok1 = next1()
+	}
+	return buildSorted(xs, a.cmp)
+}
+
+// SymmetricDifference returns a new set containing the elements that
+// appear in exactly one of a or b.
+func SymmetricDifference[T any](a, b *Set[T]) *Set[T] {
+	xs := make([]T, 0, a.size+b.size)
+	next1, stop1 := iter.Pull(a.All())
+	defer stop1()
+	next2, stop2 := iter.Pull(b.All())
+	defer stop2()
+	v1, ok1 := next1()
+	v2, ok2 := next2()
+	for ok1 && ok2 {
+		switch c := a.cmp(v1, v2); {
+		case c < 0:
+			xs = append(xs, v1)
+			v1, ok1 = next1()
+		case c > 0:
+			xs = append(xs, v2)
+			v2, ok2 = next2()
+		default:
+			v1, ok1 = next1()
+			v2, ok2 = next2()
+		}
+	}
+	for ok1 {
+		xs = append(xs, v1)
+		v1, ok1 = next1()
+	}
+	for ok2 {
+		xs = append(xs, v2)
+		v2, ok2 = next2()
+	}
+	return buildSorted(xs, a.cmp)
+}
+
+// Merge inserts every element of other into s.
+func (s *Set[T]) Merge(other *Set[T]) {
+	for v := range other.All() {
+		s.Insert(v)
+	}
+}
+
+// Subtract removes every element of other from s.
+func (s *Set[T]) Subtract(other *Set[T]) {
+	for v := range other.All() {
+		s.Remove(v)
+	}
+}
+
+// Retain removes every element of s that is not also in other.
+func (s *Set[T]) Retain(other *Set[T]) {
+	var toRemove []T
+	for v := range s.All() {
+		if !other.Contains(v) {
+			toRemove = append(toRemove, v)
+		}
+	}
+	for _, v := range toRemove {
+		s.Remove(v)
+	}
+}
+
+// Equal returns true if s and other contain the same elements.
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	if s.size != other.size {
+		return false
+	}
+	next1, stop1 := iter.Pull(s.All())
+	defer stop1()
+	next2, stop2 := iter.Pull(other.All())
+	defer stop2()
+	for {
+		v1, ok1 := next1()
+		v2, ok2 := next2()
+		if ok1 != ok2 {
+			return false
+		}
+		if !ok1 {
+			return true
+		}
+		if s.cmp(v1, v2) != 0 {
+			return false
+		}
+	}
+}
+
+// IsSubsetOf returns true if every element of s is also in other.
+func (s *Set[T]) IsSubsetOf(other *Set[T]) bool {
+	if s.size > other.size {
+		return false
+	}
+	for v := range s.All() {
+		if !other.Contains(v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,72 @@
+//go:build debug
+
+package set
+
+import "fmt"
+
+// Validate reports whether s satisfies every red-black tree invariant:
+// the root is black, no red node has a red child, every root-to-nil path
+// has equal black-height, elements are in strict ascending order, and
+// each node's size matches its subtree's node count. It is intended for
+// tests and fuzzing, not for use on a hot path, and is only compiled in
+// under the debug build tag so it never ships in a production binary.
+func (s *Set[T]) Validate() error {
+	if s.root != nil && s.root.color != black {
+		return fmt.Errorf("set: root is red")
+	}
+	var prev *T
+	count, _, err := s.validate(s.root, &prev)
+	if err != nil {
+		return err
+	}
+	if count != s.size {
+		return fmt.Errorf("set: size is %d, want %d", s.size, count)
+	}
+	return nil
+}
+
+// validate walks the subtree rooted at n in-order, checking red-red
+// violations and BST ordering against *prev (the greatest value yielded
+// so far, or nil at the start), updating *prev as it goes, and returns
+// the subtree's node count and black-height.
+func (s *Set[T]) validate(n *node[T], prev **T) (count, blackHeight int, err error) {
+	if n == nil {
+		return 0, 1, nil
+	}
+	if n.color == red {
+		if n.left != nil && n.left.color == red {
+			return 0, 0, fmt.Errorf("set: red node %v has a red left child", n.value)
+		}
+		if n.right != nil && n.right.color == red {
+			return 0, 0, fmt.Errorf("set: red node %v has a red right child", n.value)
+		}
+	}
+
+	leftCount, leftHeight, err := s.validate(n.left, prev)
+	if err != nil {
+		return 0, 0, err
+	}
+	if *prev != nil && s.cmp(**prev, n.value) >= 0 {
+		return 0, 0, fmt.Errorf("set: BST order violated at %v", n.value)
+	}
+	*prev = &n.value
+
+	rightCount, rightHeight, err := s.validate(n.right, prev)
+	if err != nil {
+		return 0, 0, err
+	}
+	if leftHeight != rightHeight {
+		return 0, 0, fmt.Errorf("set: black-height mismatch at %v: %d vs %d", n.value, leftHeight, rightHeight)
+	}
+
+	wantSize := 1 + leftCount + rightCount
+	if n.size != wantSize {
+		return 0, 0, fmt.Errorf("set: size of %v is %d, want %d", n.value, n.size, wantSize)
+	}
+
+	height := leftHeight
+	if n.color == black {
+		height++
+	}
+	return wantSize, height, nil
+}